@@ -2,6 +2,7 @@ package dbplugin
 
 import (
 	"context"
+	"io"
 	"time"
 )
 
@@ -33,6 +34,71 @@ type Database interface {
 	Close() error
 }
 
+// BatchDatabase is an optional interface a Database implementation can
+// satisfy to create or delete many users in a single plugin round trip,
+// which matters for CI/agent workloads that request hundreds of dynamic
+// creds per second. Vault probes for this interface and, when present, uses
+// NewUsers/DeleteUsers instead of calling NewUser/DeleteUser once per
+// requested item. Items are independently addressable, and a failure on one
+// item must not roll back the others.
+type BatchDatabase interface {
+	NewUsers(ctx context.Context, req BulkNewUserRequest) (BulkNewUserResponse, error)
+	DeleteUsers(ctx context.Context, req BulkDeleteUserRequest) (BulkDeleteUserResponse, error)
+}
+
+// HealthCheckable is an optional interface a Database implementation can
+// satisfy to report the liveness and readiness of its underlying connection.
+// Vault probes for this interface and, when present, uses it to gate
+// automatic root credential rotation and to short-circuit lease renewals to
+// a backend that is already known to be failing, rather than only
+// discovering the failure during a NewUser call. Plugins that don't
+// implement it are assumed to always be healthy.
+type HealthCheckable interface {
+	HealthCheck(ctx context.Context, req HealthCheckRequest) (HealthCheckResponse, error)
+}
+
+// CredentialTypeAdvertiser is an optional interface a Database implementation
+// can satisfy to advertise which CredentialType values, and, for
+// CredentialTypeSSHPrivateKey, which SSHKeyAlgorithms it can produce. Vault
+// probes for this interface at role configuration time and, when present,
+// uses it to reject unsupported combinations up front rather than waiting
+// until a credential is actually issued. Plugins that don't implement it are
+// assumed to support only CredentialTypePassword.
+type CredentialTypeAdvertiser interface {
+	SupportedCredentialTypes(ctx context.Context) (SupportedCredentialTypesResponse, error)
+}
+
+// CredentialGenerator is an optional interface a Database implementation can
+// satisfy to override Vault's default username/password generation on a
+// per-role basis. This lets plugins for systems with strict identifier rules
+// (Oracle's 30-char limit, MongoDB Atlas reserved prefixes, Snowflake
+// case-folding) enforce those constraints in one place rather than via
+// post-hoc validation of Vault-generated values.
+type CredentialGenerator interface {
+	// GenerateUsername returns a username derived from the given metadata.
+	GenerateUsername(meta UsernameMetadata) (string, error)
+
+	// GeneratePassword returns a password satisfying the given policy.
+	GeneratePassword(policy PasswordPolicy) (string, error)
+}
+
+// PasswordPolicy constrains passwords produced by CredentialGenerator.GeneratePassword.
+type PasswordPolicy struct {
+	// MinLength the generated password must meet.
+	MinLength int
+
+	// RequiredCharacterClasses the generated password must include at least
+	// one character from, e.g. "lowercase", "uppercase", "number", "symbol".
+	RequiredCharacterClasses []string
+
+	// DisallowedSubstrings must not appear anywhere in the generated password.
+	DisallowedSubstrings []string
+
+	// MaxAttempts bounds how many candidates GeneratePassword may generate
+	// internally before giving up and returning an error.
+	MaxAttempts int
+}
+
 // ///////////////////////////////////////////////////////////////////////////
 // Database Request & Response Objects
 // These request and response objects are *not* protobuf types because gRPC does not
@@ -60,6 +126,13 @@ type InitializeRequest struct {
 	// database to verify the connection can be made. If false, no connection should be made
 	// on initialization.
 	VerifyConnection bool
+
+	// RandomReader is the entropy source username and password generation should
+	// draw from. If nil, the plugin should fall back to crypto/rand.Reader. This
+	// allows an external entropy source, e.g. an HSM, to be injected. RandomReader
+	// is only meaningful for in-process Database implementations: it is not a
+	// serializable field and is not carried across the plugin gRPC boundary.
+	RandomReader io.Reader
 }
 
 // InitializeResponse returns any information Vault needs to know after initializing
@@ -99,9 +172,39 @@ type NewUserRequest struct {
 	Password string
 
 	// PublicKey credentials to use when creating the user
-	// Value is set when the credential type is 'rsa_2048_private_key'.
+	// Value is set when the credential type is 'rsa_2048_private_key' or 'ssh_private_key'.
 	PublicKey string
 
+	// PrivateKeyPassphrase is the passphrase that encrypts the private key
+	// matching PublicKey. The private key itself is not part of this request.
+	// Value is optionally set when the credential type is 'ssh_private_key'.
+	// As with Password, the gRPC conversion code that carries this across the
+	// plugin boundary lives outside this package and is not part of this change.
+	PrivateKeyPassphrase string
+
+	// JSONCredential is a structured secret to use when creating the user.
+	// Value is set when the credential type is 'json'. This allows plugins
+	// backing non-SQL systems (document stores, cloud IAM, message brokers)
+	// to receive an arbitrary structured secret rather than being shoehorned
+	// into a single Password string. Like Password, every value in this map
+	// is sensitive and must be masked key-by-key by the audit log formatter
+	// rather than logged as an opaque blob.
+	JSONCredential map[string]interface{}
+
+	// Keytab credentials to use when creating the user.
+	// Value is set when the credential type is 'kerberos_keytab'. As with
+	// Password, the gRPC conversion code that carries this across the plugin
+	// boundary lives outside this package and is not part of this change.
+	Keytab []byte
+
+	// Principal is the Kerberos principal name associated with the keytab.
+	// Value is set when the credential type is 'kerberos_keytab'.
+	Principal string
+
+	// Realm is the Kerberos realm the principal belongs to.
+	// Value is set when the credential type is 'kerberos_keytab'.
+	Realm string
+
 	// TODO: could we put the DN here? or even the certificate?
 
 	// Expiration of the user. Not all database plugins will support this.
@@ -121,6 +224,10 @@ type UsernameMetadata struct {
 	// Subject is the distinguished name for the client certificate credential.
 	// This is set when the CredentialType is 'client_certificate'.
 	Subject string
+
+	// Principal is the Kerberos principal name the plugin should construct the
+	// SPN from. This is set when the CredentialType is 'kerberos_keytab'.
+	Principal string
 }
 
 // NewUserResponse returns any information Vault needs to know after creating a new user.
@@ -137,6 +244,9 @@ const (
 	CredentialTypePassword CredentialType = iota
 	CredentialTypeRSA2048PrivateKey
 	CredentialTypeClientCertificate
+	CredentialTypeJSON
+	CredentialTypeSSHPrivateKey
+	CredentialTypeKerberosKeytab
 )
 
 func (k CredentialType) String() string {
@@ -147,11 +257,156 @@ func (k CredentialType) String() string {
 		return "rsa_2048_private_key"
 	case CredentialTypeClientCertificate:
 		return "client_certificate"
+	case CredentialTypeJSON:
+		return "json"
+	case CredentialTypeSSHPrivateKey:
+		return "ssh_private_key"
+	case CredentialTypeKerberosKeytab:
+		return "kerberos_keytab"
+	default:
+		return "unknown"
+	}
+}
+
+// SSHKeyAlgorithm is a key algorithm a plugin can generate or accept for the
+// 'ssh_private_key' credential type.
+type SSHKeyAlgorithm int
+
+const (
+	SSHKeyAlgorithmRSA SSHKeyAlgorithm = iota
+	SSHKeyAlgorithmECDSAP256
+	SSHKeyAlgorithmECDSAP384
+	SSHKeyAlgorithmECDSAP521
+	SSHKeyAlgorithmEd25519
+)
+
+func (a SSHKeyAlgorithm) String() string {
+	switch a {
+	case SSHKeyAlgorithmRSA:
+		return "rsa"
+	case SSHKeyAlgorithmECDSAP256:
+		return "ecdsa-p256"
+	case SSHKeyAlgorithmECDSAP384:
+		return "ecdsa-p384"
+	case SSHKeyAlgorithmECDSAP521:
+		return "ecdsa-p521"
+	case SSHKeyAlgorithmEd25519:
+		return "ed25519"
 	default:
 		return "unknown"
 	}
 }
 
+// ///////////////////////////////////////////////////////
+// NewUsers() / DeleteUsers()
+// ///////////////////////////////////////////////////////
+
+// BulkNewUserRequest requests that many new users be created in a single
+// plugin round trip.
+type BulkNewUserRequest struct {
+	// Items is the ordered list of users to create. Each item is independently
+	// addressable in BulkNewUserResponse.Items by its index in this slice.
+	Items []NewUserRequest
+}
+
+// BulkNewUserResponse returns the per-item result of a BulkNewUserRequest.
+// Items are returned in the same order, and at the same indices, as the
+// request so that a partial failure can be matched back to the user that
+// caused it.
+type BulkNewUserResponse struct {
+	// Items holds one result per requested user. A failure in one item does
+	// not roll back the others.
+	Items []BulkNewUserResponseItem
+}
+
+// BulkNewUserResponseItem is the outcome of creating a single user as part of
+// a BulkNewUserRequest.
+type BulkNewUserResponseItem struct {
+	// Username of the user created within the database.
+	// REQUIRED when Error is empty so Vault knows the name of the user that was created.
+	Username string
+
+	// Error is set when this item failed to be created. A non-empty Error does
+	// not affect the other items in the batch. This is a string, rather than
+	// the error interface, so the hand-written gRPC conversion for this type
+	// can carry it across the plugin boundary.
+	Error string
+}
+
+// BulkDeleteUserRequest requests that many users be deleted in a single
+// plugin round trip.
+type BulkDeleteUserRequest struct {
+	// Items is the ordered list of users to delete. Each item is independently
+	// addressable in BulkDeleteUserResponse.Items by its index in this slice.
+	Items []DeleteUserRequest
+}
+
+// BulkDeleteUserResponse returns the per-item result of a BulkDeleteUserRequest.
+type BulkDeleteUserResponse struct {
+	// Items holds one result per requested user. A failure in one item does
+	// not roll back the others.
+	Items []BulkDeleteUserResponseItem
+}
+
+// BulkDeleteUserResponseItem is the outcome of deleting a single user as part
+// of a BulkDeleteUserRequest.
+type BulkDeleteUserResponseItem struct {
+	// Error is set when this item failed to be deleted. A non-empty Error does
+	// not affect the other items in the batch. This is a string, rather than
+	// the error interface, so the hand-written gRPC conversion for this type
+	// can carry it across the plugin boundary.
+	Error string
+}
+
+// ///////////////////////////////////////////////////////
+// SupportedCredentialTypes()
+// ///////////////////////////////////////////////////////
+
+// SupportedCredentialTypesResponse describes which credential types, and
+// which options within those types, a Database implementation can produce.
+type SupportedCredentialTypesResponse struct {
+	// CredentialTypes this implementation can generate.
+	CredentialTypes []CredentialType
+
+	// SSHKeyAlgorithms this implementation can generate or accept.
+	// Only meaningful when CredentialTypes includes CredentialTypeSSHPrivateKey.
+	SSHKeyAlgorithms []SSHKeyAlgorithm
+
+	// RSAKeySizes lists the RSA key sizes, in bits, this implementation supports
+	// for CredentialTypeSSHPrivateKey when SSHKeyAlgorithms includes SSHKeyAlgorithmRSA.
+	RSAKeySizes []int
+}
+
+// ///////////////////////////////////////////////////////
+// HealthCheck()
+// ///////////////////////////////////////////////////////
+
+// HealthCheckRequest contains all information needed to check the health of
+// a database plugin's connection.
+type HealthCheckRequest struct{}
+
+// HealthCheckResponse returns the liveness and readiness of a database plugin.
+type HealthCheckResponse struct {
+	// Latency of the check performed against the underlying database.
+	Latency time.Duration
+
+	// RootCredentialValid indicates whether the root credential the plugin
+	// was initialized with is still valid.
+	RootCredentialValid bool
+
+	// CanCreateUser indicates whether the plugin is currently able to create
+	// new users.
+	CanCreateUser bool
+
+	// CanRotateRootCredential indicates whether the plugin is currently able
+	// to rotate its root credential.
+	CanRotateRootCredential bool
+
+	// CanRead indicates whether the plugin is currently able to read from the
+	// underlying database.
+	CanRead bool
+}
+
 // ///////////////////////////////////////////////////////
 // UpdateUser()
 // ///////////////////////////////////////////////////////
@@ -172,6 +427,14 @@ type UpdateUserRequest struct {
 	// If nil, no change is requested.
 	PublicKey *ChangePublicKey
 
+	// ChangeJSON indicates the new structured secret to change to.
+	// If nil, no change is requested.
+	ChangeJSON *ChangeJSON
+
+	// Keytab indicates the new Kerberos keytab to change to.
+	// If nil, no change is requested.
+	Keytab *ChangeKeytab
+
 	// Expiration indicates the new expiration date to change to.
 	// If nil, no change is requested.
 	Expiration *ChangeExpiration
@@ -187,6 +450,33 @@ type ChangePublicKey struct {
 	Statements Statements
 }
 
+// ChangeJSON of a given user
+type ChangeJSON struct {
+	// NewJSONCredential for the user
+	NewJSONCredential map[string]interface{}
+
+	// Statements is an ordered list of commands to run within the database
+	// when changing the user's structured secret.
+	Statements Statements
+}
+
+// ChangeKeytab of a given user
+type ChangeKeytab struct {
+	// NewKeytab for the user
+	NewKeytab []byte
+
+	// NewPrincipal the keytab authenticates as
+	NewPrincipal string
+
+	// NewRealm the principal belongs to
+	NewRealm string
+
+	// Statements is an ordered list of commands to run within the database
+	// when changing the user's Kerberos keytab, e.g. via `kadmin` statements
+	// templated with {{principal}} and {{keytab_b64}}.
+	Statements Statements
+}
+
 // ChangePassword of a given user
 type ChangePassword struct {
 	// NewPassword for the user
@@ -233,6 +523,8 @@ type DeleteUserResponse struct{}
 // a string slice so we can easily add more information to this in the future.
 type Statements struct {
 	// Commands is an ordered list of commands to execute in the database.
-	// These commands may include templated fields such as {{username}} and {{password}}
+	// These commands may include templated fields such as {{username}}, {{password}}
+	// and, when CredentialType is 'json', per-key fields of the structured secret
+	// rendered via {{json}}
 	Commands []string
 }